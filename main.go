@@ -0,0 +1,45 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+
+	"github.com/aellwein/terraform-provider-universe/universe"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		if err := runGenerate(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	plugin.Serve(&plugin.ServeOpts{
+		ProviderFunc: universe.Provider,
+	})
+}
+
+// runGenerate implements the `generate` subcommand:
+//
+//	terraform-provider-<name> generate --resource universe_foo --out ./examples
+//
+// It invokes the configured script's "generate" verb and writes an example
+// .tf file plus a JSON Schema document for the resource, without needing a
+// Terraform CLI at all.
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	resourceType := fs.String("resource", "", "resource type to generate an example for, e.g. universe_foo")
+	out := fs.String("out", ".", "directory to write the example and schema into")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *resourceType == "" {
+		return fmt.Errorf("generate: --resource is required")
+	}
+	return universe.GenerateExample(*resourceType, *out)
+}
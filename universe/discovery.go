@@ -0,0 +1,83 @@
+package universe
+
+import (
+	"bufio"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// EnvResourceDirVarSuffix - suffix of the OS env var naming a directory to
+// scan for resource scripts, e.g. TERRAFORM_UNIVERSE_RESOURCEDIR
+const EnvResourceDirVarSuffix = "RESOURCEDIR"
+
+// getResourceTypeNamesFromDirectory scans TERRAFORM_<NAME>_RESOURCEDIR, if
+// set, and returns a resourceConfig per file found there: the resource type
+// name is "<providerName>_<file base name without extension>", and its
+// executor/script default to the file's shebang interpreter and its path.
+// Files without a usable shebang are skipped.
+func getResourceTypeNamesFromDirectory(providerName string) map[string]*resourceConfig {
+	dirVarName := "TERRAFORM_" + strings.ToUpper(providerName) + "_" + EnvResourceDirVarSuffix
+	dir, ok := os.LookupEnv(dirVarName)
+	if !ok {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		log.Printf("failed to read resource directory %s: %v\n", dir, err)
+		return nil
+	}
+
+	result := map[string]*resourceConfig{}
+	prefix := providerName + "_"
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		executor, ok := shebangInterpreter(path)
+		if !ok {
+			continue
+		}
+		base := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		result[prefix+base] = &resourceConfig{
+			Executor: executor,
+			Script:   path,
+		}
+	}
+	return result
+}
+
+// shebangInterpreter reads the first line of path and, if it is a shebang
+// ("#!/usr/bin/env python" or "#!/usr/bin/python3"), returns the
+// interpreter name.
+func shebangInterpreter(path string) (string, bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return "", false
+	}
+	line := strings.TrimSpace(scanner.Text())
+	if !strings.HasPrefix(line, "#!") {
+		return "", false
+	}
+	fields := strings.Fields(strings.TrimPrefix(line, "#!"))
+	if len(fields) == 0 {
+		return "", false
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		if len(fields) < 2 {
+			return "", false
+		}
+		interpreter = fields[1]
+	}
+	return interpreter, true
+}
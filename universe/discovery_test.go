@@ -0,0 +1,81 @@
+package universe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestShebangInterpreter(t *testing.T) {
+	dir := t.TempDir()
+
+	cases := map[string]struct {
+		content string
+		wantExe string
+		wantOk  bool
+	}{
+		"direct shebang":        {content: "#!/usr/bin/python3\nprint('hi')\n", wantExe: "python3", wantOk: true},
+		"env indirection":       {content: "#!/usr/bin/env python\nprint('hi')\n", wantExe: "python", wantOk: true},
+		"no shebang":            {content: "echo hi\n", wantOk: false},
+		"empty env indirection": {content: "#!/usr/bin/env\n", wantOk: false},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".sh")
+			if err := os.WriteFile(path, []byte(tc.content), 0o755); err != nil {
+				t.Fatalf("writing %s: %v", path, err)
+			}
+			exe, ok := shebangInterpreter(path)
+			if ok != tc.wantOk {
+				t.Fatalf("shebangInterpreter(%q) ok = %v, want %v", tc.content, ok, tc.wantOk)
+			}
+			if ok && exe != tc.wantExe {
+				t.Fatalf("shebangInterpreter(%q) = %q, want %q", tc.content, exe, tc.wantExe)
+			}
+		})
+	}
+}
+
+func TestGetResourceTypeNamesFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writeExecutable(t, filepath.Join(dir, "foo.py"), "#!/usr/bin/env python\n")
+	writeExecutable(t, filepath.Join(dir, "bar"), "#!/usr/bin/bash\n")
+	writeExecutable(t, filepath.Join(dir, "notascript.txt"), "just text\n")
+	if err := os.Mkdir(filepath.Join(dir, "subdir"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+
+	varName := "TERRAFORM_" + "UNIVERSE" + "_" + EnvResourceDirVarSuffix
+	t.Setenv(varName, dir)
+
+	got := getResourceTypeNamesFromDirectory("universe")
+
+	if len(got) != 2 {
+		t.Fatalf("got %d resource types, want 2: %#v", len(got), got)
+	}
+	foo, ok := got["universe_foo"]
+	if !ok || foo.Executor != "python" {
+		t.Fatalf("universe_foo = %#v, ok=%v", foo, ok)
+	}
+	bar, ok := got["universe_bar"]
+	if !ok || bar.Executor != "bash" {
+		t.Fatalf("universe_bar = %#v, ok=%v", bar, ok)
+	}
+	if _, ok := got["universe_notascript"]; ok {
+		t.Fatalf("expected non-shebang file to be skipped, got %#v", got["universe_notascript"])
+	}
+}
+
+func TestGetResourceTypeNamesFromDirectoryMissingVar(t *testing.T) {
+	if got := getResourceTypeNamesFromDirectory("universe-unset-in-this-test"); got != nil {
+		t.Fatalf("expected nil when the env var is unset, got %#v", got)
+	}
+}
+
+func writeExecutable(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o755); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
@@ -0,0 +1,196 @@
+package universe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GenerateExample runs resourceType's configured script with the "generate"
+// verb, captures the attribute schema it emits on stdout as JSON, and
+// writes both a Terraform example .tf file and a JSON Schema document for
+// the resource into outDir. It requires no Terraform CLI, so it is safe to
+// wire into CI.
+func GenerateExample(resourceType, outDir string) error {
+	cfg, err := resolveGenerateConfig(resourceType)
+	if err != nil {
+		return err
+	}
+
+	raw, err := runAction(cfg, "generate", nil)
+	if err != nil {
+		return fmt.Errorf("running generate for %s: %w", resourceType, err)
+	}
+
+	var attrs map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &attrs); err != nil {
+		return fmt.Errorf("parsing generate output as JSON: %w", err)
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+	if err := writeExampleTF(outDir, resourceType, attrs); err != nil {
+		return err
+	}
+	return writeJSONSchema(outDir, resourceType, attrs)
+}
+
+// resolveGenerateConfig looks up resourceType's executor/script (or
+// javascript) from the manifest and resource directory, the same sources
+// Provider() uses, since the CLI has no schema.ResourceData to configure
+// from. In multi-provider mode (see multiprovider.go), it also folds in
+// each logical provider's own manifest/directory overrides, in the same
+// sorted-name order and with the same first-registration-wins precedence
+// Provider() uses to build its ResourcesMap, so "generate" and the running
+// provider always resolve a given (possibly colliding) type name to the
+// same script.
+func resolveGenerateConfig(resourceType string) (*resourceConfig, error) {
+	providerName := getProviderNameFromBinaryOrEnvironment()
+	overrides := resolveOverrides(providerName)
+	if overrides == nil {
+		overrides = map[string]*resourceConfig{}
+	}
+
+	multiProviders := parseMultiProviders()
+	for _, logicalName := range sortedLogicalProviderNames(multiProviders) {
+		defaultCfg := multiProviders[logicalName]
+		logicalOverrides := resolveOverrides(logicalName)
+		if logicalOverrides == nil {
+			logicalOverrides = map[string]*resourceConfig{}
+		}
+		if _, ok := logicalOverrides[logicalName]; !ok {
+			logicalOverrides[logicalName] = defaultCfg
+		}
+		for name, cfg := range logicalOverrides {
+			if _, ok := overrides[name]; !ok {
+				overrides[name] = cfg
+			}
+		}
+	}
+
+	cfg, ok := overrides[resourceType]
+	if !ok {
+		return nil, fmt.Errorf("no executor/script configured for resource type %q", resourceType)
+	}
+	if cfg.JavaScript == "" && (cfg.Executor == "" || cfg.Script == "") {
+		return nil, fmt.Errorf("resource type %q has no executor/script or javascript configured", resourceType)
+	}
+	return cfg, nil
+}
+
+// writeExampleTF writes a ready-to-run example .tf file for resourceType.
+// resourceCustom() declares a single "state" attribute for every resource
+// type (the actual state is opaque JSON owned by the script), so the
+// attributes the "generate" verb emitted are rendered as the object
+// literal passed to jsonencode() rather than as top-level arguments -
+// anything else would not be valid input for the resource's real schema.
+func writeExampleTF(outDir, resourceType string, attrs map[string]interface{}) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "resource %q %q {\n", resourceType, "example")
+	fmt.Fprintf(&b, "  state = jsonencode(%s)\n", hclValue(attrs, 1))
+	b.WriteString("}\n")
+	path := filepath.Join(outDir, resourceType+".tf")
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// hclValue renders a decoded JSON value as an HCL expression, indented for
+// nesting inside a jsonencode(...) call at the given depth.
+func hclValue(v interface{}, depth int) string {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return hclObject(val, depth)
+	case []interface{}:
+		return hclArray(val, depth)
+	case string:
+		return strconv.Quote(val)
+	case bool:
+		return strconv.FormatBool(val)
+	case float64:
+		return strconv.FormatFloat(val, 'g', -1, 64)
+	case nil:
+		return "null"
+	default:
+		return strconv.Quote(fmt.Sprintf("%v", val))
+	}
+}
+
+// hclObject renders attrs as an indented HCL object literal with keys in
+// stable, sorted order so generated examples are reproducible.
+func hclObject(attrs map[string]interface{}, depth int) string {
+	if len(attrs) == 0 {
+		return "{}"
+	}
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	indent := strings.Repeat("  ", depth+1)
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s%s = %s\n", indent, k, hclValue(attrs[k], depth+1))
+	}
+	fmt.Fprintf(&b, "%s}", strings.Repeat("  ", depth))
+	return b.String()
+}
+
+// hclArray renders v as an indented HCL tuple literal.
+func hclArray(v []interface{}, depth int) string {
+	if len(v) == 0 {
+		return "[]"
+	}
+	indent := strings.Repeat("  ", depth+1)
+	var b strings.Builder
+	b.WriteString("[\n")
+	for _, elem := range v {
+		fmt.Fprintf(&b, "%s%s,\n", indent, hclValue(elem, depth+1))
+	}
+	fmt.Fprintf(&b, "%s]", strings.Repeat("  ", depth))
+	return b.String()
+}
+
+// writeJSONSchema writes a JSON Schema document describing resourceType's
+// attributes, inferring each property's type from the example value the
+// script's "generate" verb emitted.
+func writeJSONSchema(outDir, resourceType string, attrs map[string]interface{}) error {
+	properties := map[string]interface{}{}
+	for k, v := range attrs {
+		properties[k] = map[string]string{"type": jsonSchemaType(v)}
+	}
+	schemaDoc := map[string]interface{}{
+		"$schema":    "http://json-schema.org/draft-07/schema#",
+		"title":      resourceType,
+		"type":       "object",
+		"properties": properties,
+	}
+	out, err := json.MarshalIndent(schemaDoc, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(outDir, resourceType+".schema.json")
+	return os.WriteFile(path, out, 0o644)
+}
+
+// jsonSchemaType maps a decoded JSON value to the JSON Schema "type" keyword
+// that describes it.
+func jsonSchemaType(v interface{}) string {
+	switch v.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return "string"
+	}
+}
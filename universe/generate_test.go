@@ -0,0 +1,32 @@
+package universe
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolveGenerateConfigMultiProviderMode(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "foo.py")
+	if err := os.WriteFile(script, []byte("#!/usr/bin/env python\n"), 0o755); err != nil {
+		t.Fatalf("writing %s: %v", script, err)
+	}
+
+	t.Setenv(EnvProvidersVar, "aws-lite="+script)
+	t.Setenv("TERRAFORM_AWS-LITE_"+EnvResourceDirVarSuffix, dir)
+
+	cfg, err := resolveGenerateConfig("aws-lite_foo")
+	if err != nil {
+		t.Fatalf("resolveGenerateConfig(aws-lite_foo): %v", err)
+	}
+	if cfg.Executor != "python" || cfg.Script != script {
+		t.Fatalf("resolveGenerateConfig(aws-lite_foo) = %#v, want Executor=python Script=%s", cfg, script)
+	}
+}
+
+func TestResolveGenerateConfigUnknownType(t *testing.T) {
+	if _, err := resolveGenerateConfig("universe_does_not_exist"); err == nil {
+		t.Fatal("expected an error for an unregistered resource type, got nil")
+	}
+}
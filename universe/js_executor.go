@@ -0,0 +1,212 @@
+package universe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+const (
+	// defaultJavaScriptTimeout bounds how long a single create/read/update/
+	// delete call is allowed to run before its VM is interrupted.
+	defaultJavaScriptTimeout = 30 * time.Second
+
+	// defaultJavaScriptMaxStackSize caps call-stack depth, so unbounded
+	// recursion fails fast with a goja StackOverflowError instead of
+	// growing without limit. goja's own default (math.MaxInt32) is
+	// effectively unbounded, so the cap is set explicitly on every VM.
+	defaultJavaScriptMaxStackSize = 512
+
+	// defaultJavaScriptMaxMemoryBytes bounds how much the Go heap may grow
+	// over its pre-call baseline while one create/read/update/delete call
+	// runs. goja exposes no direct heap quota, so this is enforced by
+	// sampling runtime.MemStats from a watchdog goroutine and interrupting
+	// the VM if the cap is exceeded - an approximation, but one that
+	// catches the common "allocate forever" failure mode. runtime.MemStats
+	// is process-wide, not per-call, so javascriptInvocationMu serializes
+	// all javascript invocations to keep the watchdog's baseline
+	// meaningful; see runJavaScript.
+	defaultJavaScriptMaxMemoryBytes = 128 * 1024 * 1024
+
+	memoryWatchInterval = 10 * time.Millisecond
+)
+
+// javascriptInvocationMu serializes calls to runJavaScript process-wide.
+// Terraform's SDK dispatches CRUD calls for independent resources
+// concurrently (default -parallelism=10), and runtime.MemStats.HeapAlloc is
+// shared across the whole process, so without this lock one resource's
+// call could blow another's memory budget, or a leaking script could dodge
+// the cap if a concurrent call's garbage collection happened to lower heap
+// readings at the same time. Holding this for the whole call trades
+// concurrent JS execution for a cap that actually bounds one invocation.
+var javascriptInvocationMu sync.Mutex
+
+// runJavaScript runs verb ("create", "read", "update" or "delete") as the
+// matching exported function in cfg.JavaScript, in a fresh goja VM. State is
+// passed in as a JS object; the function's return value is marshalled back
+// to JSON and becomes the resource's new state.
+//
+// cfg.JavaScript may be a path to a .js file or inline source; a path is
+// preferred whenever the value names an existing file, so scripts can
+// require() helper libraries relative to their own directory.
+//
+// verb "delete" is looked up as "destroy", not "delete": `delete` is an
+// ECMAScript reserved word, so `function delete(state) {}` is a
+// SyntaxError in goja (and any ES5 engine) - there is no way to declare a
+// function literally named delete. Scripts must export destroy(state) for
+// the delete verb instead.
+func runJavaScript(cfg *resourceConfig, verb string, state interface{}) (string, error) {
+	javascriptInvocationMu.Lock()
+	defer javascriptInvocationMu.Unlock()
+
+	source, dir, err := loadJavaScriptSource(cfg.JavaScript)
+	if err != nil {
+		return "", err
+	}
+
+	vm := goja.New()
+
+	maxStackSize := cfg.JavaScriptMaxStackSize
+	if maxStackSize == 0 {
+		maxStackSize = defaultJavaScriptMaxStackSize
+	}
+	vm.SetMaxCallStackSize(maxStackSize)
+
+	timeout := cfg.JavaScriptTimeout
+	if timeout == 0 {
+		timeout = defaultJavaScriptTimeout
+	}
+	timer := time.AfterFunc(timeout, func() {
+		vm.Interrupt(fmt.Errorf("javascript %s timed out after %s", verb, timeout))
+	})
+	defer timer.Stop()
+
+	maxMemoryBytes := cfg.JavaScriptMaxMemoryBytes
+	if maxMemoryBytes == 0 {
+		maxMemoryBytes = defaultJavaScriptMaxMemoryBytes
+	}
+	stopWatch := make(chan struct{})
+	defer close(stopWatch)
+	go watchJavaScriptMemory(vm, maxMemoryBytes, verb, stopWatch)
+
+	vm.Set("require", makeRequire(vm, dir))
+	if _, err := vm.RunScript(cfg.JavaScript, source); err != nil {
+		return "", fmt.Errorf("loading javascript: %w", err)
+	}
+
+	fnName := exportedFunctionName(verb)
+	fn, ok := goja.AssertFunction(vm.Get(fnName))
+	if !ok {
+		return "", fmt.Errorf("javascript source has no exported %q function", fnName)
+	}
+
+	// Resource state arrives as a JSON string (see resourceCustomContext);
+	// decode it so the script receives it as a JS object.
+	if s, ok := state.(string); ok && s != "" {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(s), &decoded); err == nil {
+			state = decoded
+		}
+	}
+
+	var args []goja.Value
+	switch verb {
+	case "create", "update":
+		args = []goja.Value{vm.ToValue(state), vm.ToValue(cfg.Environment)}
+	default:
+		args = []goja.Value{vm.ToValue(state)}
+	}
+
+	result, err := fn(goja.Undefined(), args...)
+	if err != nil {
+		return "", fmt.Errorf("running javascript %s: %w", verb, err)
+	}
+
+	out, err := json.Marshal(result.Export())
+	if err != nil {
+		return "", fmt.Errorf("marshalling javascript result: %w", err)
+	}
+	return string(out), nil
+}
+
+// exportedFunctionName maps a CRUD verb to the name of the function a
+// script must export for it. Every verb maps to itself except "delete",
+// which maps to "destroy" since `delete` is a reserved ECMAScript keyword
+// and cannot be used as a function declaration's name.
+func exportedFunctionName(verb string) string {
+	if verb == "delete" {
+		return "destroy"
+	}
+	return verb
+}
+
+// watchJavaScriptMemory samples the Go heap every memoryWatchInterval and
+// interrupts vm once it has grown by more than maxBytes over its baseline
+// at call start. It returns when stop is closed.
+func watchJavaScriptMemory(vm *goja.Runtime, maxBytes uint64, verb string, stop <-chan struct{}) {
+	var baseline runtime.MemStats
+	runtime.ReadMemStats(&baseline)
+
+	ticker := time.NewTicker(memoryWatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			var cur runtime.MemStats
+			runtime.ReadMemStats(&cur)
+			if cur.HeapAlloc > baseline.HeapAlloc+maxBytes {
+				vm.Interrupt(fmt.Errorf("javascript %s exceeded memory cap of %d bytes", verb, maxBytes))
+				return
+			}
+		}
+	}
+}
+
+// loadJavaScriptSource returns the source to run and the directory relative
+// paths should be resolved against. If spec names an existing file its
+// contents are read; otherwise spec is treated as inline source.
+func loadJavaScriptSource(spec string) (source, dir string, err error) {
+	if content, err := os.ReadFile(spec); err == nil {
+		return string(content), filepath.Dir(spec), nil
+	}
+	return spec, ".", nil
+}
+
+// makeRequire gives scripts a minimal CommonJS-style require() that loads a
+// sibling .js file, relative to dir, and returns its module.exports.
+func makeRequire(vm *goja.Runtime, dir string) func(string) goja.Value {
+	return func(module string) goja.Value {
+		path := module
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		if filepath.Ext(path) == "" {
+			path += ".js"
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			panic(vm.ToValue(fmt.Sprintf("require(%q): %v", module, err)))
+		}
+		wrapped := "(function(module, exports) {" + string(src) + "\n})"
+		fn, err := vm.RunString(wrapped)
+		if err != nil {
+			panic(vm.ToValue(fmt.Sprintf("require(%q): %v", module, err)))
+		}
+		call, _ := goja.AssertFunction(fn)
+		moduleObj := vm.NewObject()
+		exportsObj := vm.NewObject()
+		_ = moduleObj.Set("exports", exportsObj)
+		if _, err := call(goja.Undefined(), moduleObj, exportsObj); err != nil {
+			panic(vm.ToValue(fmt.Sprintf("require(%q): %v", module, err)))
+		}
+		return moduleObj.Get("exports")
+	}
+}
@@ -0,0 +1,89 @@
+package universe
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestRunJavaScriptDestroyVerb verifies that the "delete" CRUD verb invokes
+// a script's exported destroy(state) function, since `delete` itself
+// cannot be declared as a function name in ECMAScript.
+func TestRunJavaScriptDestroyVerb(t *testing.T) {
+	cfg := &resourceConfig{JavaScript: `
+		function create(state, config) { return state; }
+		function read(state) { return state; }
+		function update(state, config) { return state; }
+		function destroy(state) { return { deleted: true }; }
+	`}
+
+	out, err := runJavaScript(cfg, "delete", map[string]interface{}{"id": "abc"})
+	if err != nil {
+		t.Fatalf("runJavaScript(delete): %v", err)
+	}
+	if !strings.Contains(out, `"deleted":true`) {
+		t.Fatalf("expected destroy() result in output, got %q", out)
+	}
+}
+
+// TestRunJavaScriptDeleteFunctionNameIsInvalid documents why the exported
+// hook for the delete verb is named destroy, not delete: a script that
+// tries to declare `function delete(state) {}`, as the reserved-word-naive
+// reading of the CRUD contract would suggest, fails to parse at all.
+func TestRunJavaScriptDeleteFunctionNameIsInvalid(t *testing.T) {
+	cfg := &resourceConfig{JavaScript: `function delete(state) { return state; }`}
+
+	if _, err := runJavaScript(cfg, "delete", map[string]interface{}{}); err == nil {
+		t.Fatal("expected a SyntaxError from a function literally named delete, got nil")
+	}
+}
+
+// TestRunJavaScriptMissingExportedFunction checks the error names the
+// expected exported function, not the raw CRUD verb, for the delete case.
+func TestRunJavaScriptMissingExportedFunction(t *testing.T) {
+	cfg := &resourceConfig{JavaScript: `function create(state) { return state; }`}
+
+	_, err := runJavaScript(cfg, "delete", map[string]interface{}{})
+	if err == nil || !strings.Contains(err.Error(), `"destroy"`) {
+		t.Fatalf("expected error naming the missing %q function, got %v", "destroy", err)
+	}
+}
+
+// TestRunJavaScriptConcurrentCallsDoNotShareMemoryBudget exercises two
+// concurrent runJavaScript calls - one allocating well under its own
+// memory cap, one well over it - the same way Terraform's SDK dispatches
+// concurrent CRUD calls for independent resources. Without
+// javascriptInvocationMu serializing invocations, runtime.MemStats'
+// process-wide HeapAlloc would let the leaky call's allocations trip the
+// lean call's unrelated budget.
+func TestRunJavaScriptConcurrentCallsDoNotShareMemoryBudget(t *testing.T) {
+	leanCfg := &resourceConfig{
+		JavaScript:               `function create(state) { return { ok: true }; }`,
+		JavaScriptMaxMemoryBytes: 8 * 1024 * 1024,
+	}
+	leakyCfg := &resourceConfig{
+		JavaScript: `function create(state) {
+			var chunks = [];
+			for (var i = 0; i < 200; i++) { chunks.push(new Array(100000).join("x")); }
+			return { ok: true };
+		}`,
+		JavaScriptMaxMemoryBytes: 1,
+	}
+
+	var wg sync.WaitGroup
+	var leanErr error
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, leanErr = runJavaScript(leanCfg, "create", map[string]interface{}{})
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = runJavaScript(leakyCfg, "create", map[string]interface{}{})
+	}()
+	wg.Wait()
+
+	if leanErr != nil {
+		t.Fatalf("lean call was spuriously affected by a concurrent leaky call: %v", leanErr)
+	}
+}
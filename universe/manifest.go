@@ -0,0 +1,86 @@
+package universe
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsimple"
+	"gopkg.in/yaml.v2"
+)
+
+// manifestFile is the top-level shape of a manifest, in both its HCL and
+// YAML forms: a list of "resource" blocks, one per resource type, giving
+// the executor/script/id_key/environment to use for that type alone.
+type manifestFile struct {
+	Resources []manifestResource `hcl:"resource,block" yaml:"resource"`
+}
+
+// manifestResource is a single "resource" block/entry inside a manifest,
+// keyed by the fully-qualified resource type name, e.g. "universe_foo".
+type manifestResource struct {
+	Type        string            `hcl:"type,label" yaml:"type"`
+	Executor    string            `hcl:"executor,optional" yaml:"executor"`
+	Script      string            `hcl:"script,optional" yaml:"script"`
+	IDKey       string            `hcl:"id_key,optional" yaml:"id_key"`
+	Environment map[string]string `hcl:"environment,optional" yaml:"environment"`
+	JavaScript  string            `hcl:"javascript,optional" yaml:"javascript"`
+	// Provider explicitly names the logical provider (see multiprovider.go)
+	// this resource type routes to in multi-provider mode, overriding the
+	// default of routing by the type name's own prefix. Only meaningful
+	// when TERRAFORM_UNIVERSE_PROVIDERS is set.
+	Provider string `hcl:"provider,optional" yaml:"provider"`
+}
+
+// EnvManifestVarSuffix - suffix of the OS env var naming the manifest file,
+// e.g. TERRAFORM_UNIVERSE_MANIFEST
+const EnvManifestVarSuffix = "MANIFEST"
+
+// getManifestPathFromEnvironment resolves the manifest path from
+// TERRAFORM_<NAME>_MANIFEST. Returns "" if unset.
+//
+// There is deliberately no provider-schema equivalent: ResourcesMap is
+// built by Provider(), before any schema.ResourceData exists to read a
+// "manifest" argument from, so only the env var can actually drive which
+// resource types get registered.
+func getManifestPathFromEnvironment(providerName string) string {
+	envVar := "TERRAFORM_" + strings.ToUpper(providerName) + "_" + EnvManifestVarSuffix
+	return os.Getenv(envVar)
+}
+
+// loadManifest reads path (HCL by file extension .hcl/.tf, YAML otherwise)
+// and returns its resource overrides keyed by resource type name.
+func loadManifest(path string) (map[string]*resourceConfig, error) {
+	var mf manifestFile
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".hcl", ".tf":
+		if err := hclsimple.DecodeFile(path, nil, &mf); err != nil {
+			return nil, err
+		}
+	default:
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(raw, &mf); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make(map[string]*resourceConfig, len(mf.Resources))
+	for _, r := range mf.Resources {
+		if r.Type == "" {
+			return nil, fmt.Errorf("manifest %s has a resource entry with no type", path)
+		}
+		result[r.Type] = &resourceConfig{
+			Executor:    r.Executor,
+			Script:      r.Script,
+			IDKey:       r.IDKey,
+			Environment: r.Environment,
+			JavaScript:  r.JavaScript,
+			Provider:    r.Provider,
+		}
+	}
+	return result, nil
+}
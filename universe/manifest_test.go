@@ -0,0 +1,92 @@
+package universe
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadManifestYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	writeFile(t, path, `
+resource:
+  - type: universe_foo
+    executor: python3
+    script: ./foo.py
+    id_key: uuid
+    environment:
+      FOO: bar
+  - type: aws-lite_bucket
+    javascript: ./bucket.js
+    provider: aws-lite
+`)
+
+	got, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+
+	want := map[string]*resourceConfig{
+		"universe_foo": {
+			Executor:    "python3",
+			Script:      "./foo.py",
+			IDKey:       "uuid",
+			Environment: map[string]string{"FOO": "bar"},
+		},
+		"aws-lite_bucket": {
+			JavaScript: "./bucket.js",
+			Provider:   "aws-lite",
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("loadManifest = %#v, want %#v", got, want)
+	}
+}
+
+func TestLoadManifestHCL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.hcl")
+	writeFile(t, path, `
+resource "universe_foo" {
+  executor = "python3"
+  script   = "./foo.py"
+}
+
+resource "aws-lite_bucket" {
+  javascript = "./bucket.js"
+  provider   = "aws-lite"
+}
+`)
+
+	got, err := loadManifest(path)
+	if err != nil {
+		t.Fatalf("loadManifest: %v", err)
+	}
+
+	if got["universe_foo"].Executor != "python3" || got["universe_foo"].Script != "./foo.py" {
+		t.Fatalf("universe_foo = %#v", got["universe_foo"])
+	}
+	if got["aws-lite_bucket"].Provider != "aws-lite" || got["aws-lite_bucket"].JavaScript != "./bucket.js" {
+		t.Fatalf("aws-lite_bucket = %#v", got["aws-lite_bucket"])
+	}
+}
+
+func TestLoadManifestRejectsMissingType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	writeFile(t, path, `
+resource:
+  - executor: python3
+    script: ./foo.py
+`)
+
+	if _, err := loadManifest(path); err == nil {
+		t.Fatal("expected an error for a resource entry with no type, got nil")
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing %s: %v", path, err)
+	}
+}
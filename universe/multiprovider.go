@@ -0,0 +1,76 @@
+package universe
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// EnvProvidersVar - name of the OS env var enabling multi-provider mode: a
+// whitespace-separated list of "<name>=<script>" pairs, e.g.
+// "aws-lite=./aws.py cloudflare-lite=./cf.py". Each pair registers a
+// logical provider whose resource types are namespaced under "<name>_".
+const EnvProvidersVar = "TERRAFORM_UNIVERSE_PROVIDERS"
+
+// parseMultiProviders reads EnvProvidersVar, if set, and returns one
+// default resourceConfig per logical provider name, keyed by that name.
+// Each config's Executor is inferred from its script's shebang, same as
+// directory auto-discovery.
+func parseMultiProviders() map[string]*resourceConfig {
+	spec, ok := os.LookupEnv(EnvProvidersVar)
+	if !ok {
+		return nil
+	}
+
+	result := map[string]*resourceConfig{}
+	for _, pair := range strings.Fields(spec) {
+		name, script, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		cfg := &resourceConfig{Script: script}
+		if executor, ok := shebangInterpreter(script); ok {
+			cfg.Executor = executor
+		}
+		result[name] = cfg
+	}
+	return result
+}
+
+// sortedLogicalProviderNames returns multiProviders' keys (the logical
+// provider names from parseMultiProviders) sorted lexically. Callers that
+// fold each logical provider's resource types into a shared map, where a
+// type name collision must resolve the same way on every run, need a
+// deterministic iteration order - Go's own map iteration order is
+// randomized.
+func sortedLogicalProviderNames(multiProviders map[string]*resourceConfig) []string {
+	names := make([]string, 0, len(multiProviders))
+	for name := range multiProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// resourceTypePrefix returns the logical provider prefix of a resource type
+// name, i.e. the part before the first underscore (e.g. "aws-lite" for
+// "aws-lite_bucket").
+func resourceTypePrefix(typeName string) string {
+	prefix, _, ok := strings.Cut(typeName, "_")
+	if !ok {
+		return typeName
+	}
+	return prefix
+}
+
+// logicalProviderFor resolves which logical provider a resource type routes
+// to: override's explicit Provider mapping, if set, otherwise the type
+// name's own prefix. This mirrors Terraform's own
+// ResourceProviderFullName(resourceType, explicitProvider) resolution,
+// where an explicit provider mapping always wins over the type's name.
+func logicalProviderFor(typeName string, override *resourceConfig) string {
+	if override != nil && override.Provider != "" {
+		return override.Provider
+	}
+	return resourceTypePrefix(typeName)
+}
@@ -0,0 +1,44 @@
+package universe
+
+import "testing"
+
+func TestLogicalProviderFor(t *testing.T) {
+	cases := []struct {
+		name     string
+		typeName string
+		override *resourceConfig
+		want     string
+	}{
+		{name: "no override falls back to prefix", typeName: "aws-lite_bucket", override: nil, want: "aws-lite"},
+		{name: "override with no Provider falls back to prefix", typeName: "aws-lite_bucket", override: &resourceConfig{}, want: "aws-lite"},
+		{name: "explicit Provider overrides the prefix", typeName: "aws-lite_bucket", override: &resourceConfig{Provider: "cloudflare-lite"}, want: "cloudflare-lite"},
+		{name: "no underscore in type name", typeName: "universe", override: nil, want: "universe"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := logicalProviderFor(tc.typeName, tc.override); got != tc.want {
+				t.Fatalf("logicalProviderFor(%q, %#v) = %q, want %q", tc.typeName, tc.override, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSortedLogicalProviderNames(t *testing.T) {
+	multiProviders := map[string]*resourceConfig{
+		"zeta":  {},
+		"alpha": {},
+		"mid":   {},
+	}
+
+	got := sortedLogicalProviderNames(multiProviders)
+	want := []string{"alpha", "mid", "zeta"}
+	if len(got) != len(want) {
+		t.Fatalf("sortedLogicalProviderNames = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sortedLogicalProviderNames = %v, want %v", got, want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -67,23 +68,78 @@ func getResourceTypeNamesFromEnvironment(providerName string) (result map[string
 	return
 }
 
-func getResourceMap(providerName string) (result map[string]*schema.Resource) {
+// getResourceMap builds the ResourcesMap for the provider: one entry per
+// discovered resource type name, each backed by resourceCustom(). Entries
+// present in overrides get that manifest entry's executor/script/id_key/
+// environment baked in as a per-type default; the rest fall back entirely
+// to the provider-level configuration resolved at apply time.
+func getResourceMap(providerName string, overrides map[string]*resourceConfig) (result map[string]*schema.Resource) {
 	result = make(map[string]*schema.Resource)
 	for resourceName := range getResourceTypeNamesFromEnvironment(providerName) {
-		result[resourceName] = resourceCustom()
+		result[resourceName] = resourceCustom(resourceName, overrides[resourceName])
+	}
+	for resourceName := range overrides {
+		if _, ok := result[resourceName]; !ok {
+			result[resourceName] = resourceCustom(resourceName, overrides[resourceName])
+		}
 	}
 	log.Printf("resourceMap is: %#v\n", result)
 	return
 }
 
+// resolveOverrides merges a provider's manifest overrides with its
+// directory-discovered ones, manifest taking precedence for any type name
+// declared by both.
+func resolveOverrides(providerName string) map[string]*resourceConfig {
+	overrides := loadManifestOverrides(providerName)
+	for name, cfg := range getResourceTypeNamesFromDirectory(providerName) {
+		if _, ok := overrides[name]; ok {
+			continue
+		}
+		if overrides == nil {
+			overrides = map[string]*resourceConfig{}
+		}
+		overrides[name] = cfg
+	}
+	return overrides
+}
+
 // Provider ...
 func Provider() *schema.Provider {
 	// Get the provider name to use
 	providerName := getProviderNameFromBinaryOrEnvironment()
 	log.Printf("universe provider name is: %s\n", providerName)
 
+	// Get the manifest and directory-discovered resource types
+	overrides := resolveOverrides(providerName)
+
 	// Get the resource names
-	resourceMap := getResourceMap(providerName)
+	resourceMap := getResourceMap(providerName, overrides)
+
+	// Multi-provider mode: fold in resource types for each logical provider
+	// declared via TERRAFORM_UNIVERSE_PROVIDERS, namespaced under its own
+	// prefix, so one binary can front several unrelated scripts. Logical
+	// providers are visited in sorted name order, and the first provider to
+	// register a type name wins on a collision - the same precedence
+	// resolveGenerateConfig uses, so "generate" and the running provider
+	// never resolve a colliding type name to different scripts.
+	multiProviders := parseMultiProviders()
+	for _, logicalName := range sortedLogicalProviderNames(multiProviders) {
+		defaultCfg := multiProviders[logicalName]
+		logicalOverrides := resolveOverrides(logicalName)
+		if logicalOverrides == nil {
+			logicalOverrides = map[string]*resourceConfig{}
+		}
+		if _, ok := logicalOverrides[logicalName]; !ok {
+			logicalOverrides[logicalName] = defaultCfg
+		}
+		for typeName, r := range getResourceMap(logicalName, logicalOverrides) {
+			if _, ok := resourceMap[typeName]; !ok {
+				resourceMap[typeName] = r
+			}
+		}
+	}
+
 	for n := range resourceMap {
 		log.Printf("provider %s has resource %s\n", providerName, n)
 	}
@@ -115,11 +171,49 @@ func Provider() *schema.Provider {
 					Type: schema.TypeString,
 				},
 			},
+			"javascript": {
+				Description: "Path to a .js file, or inline JavaScript source, run in-process instead of spawning 'executor'. Must export create/read/update/destroy functions; destroy handles deletes, since delete is a reserved word in JavaScript.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"javascript_timeout_seconds": {
+				Description: "Maximum number of seconds a single javascript create/read/update/delete call may run before being interrupted. Defaults to 30.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"javascript_max_stack_size": {
+				Description: "Maximum call-stack depth for a single javascript invocation, to bound recursion. Defaults to 512.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
+			"javascript_max_memory_bytes": {
+				Description: "Maximum bytes the Go heap may grow by during a single javascript invocation before it is interrupted. Defaults to 128MiB.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+			},
 		},
 	}
 	return p
 }
 
+// loadManifestOverrides resolves and loads the manifest file for
+// providerName from TERRAFORM_<NAME>_MANIFEST, if set. There is no
+// provider-schema equivalent: ResourcesMap is built by Provider(), before
+// any schema.ResourceData exists, so the env var is the only thing that
+// can actually drive which resource types get registered.
+func loadManifestOverrides(providerName string) map[string]*resourceConfig {
+	path := getManifestPathFromEnvironment(providerName)
+	if path == "" {
+		return nil
+	}
+	overrides, err := loadManifest(path)
+	if err != nil {
+		log.Printf("failed to load manifest %s: %v\n", path, err)
+		return nil
+	}
+	return overrides
+}
+
 // providerConfigureV2 - Map to normal function without lame, untestable v2 Diagnostics
 func providerConfigureV2(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	result, err := providerConfigure(d)
@@ -129,15 +223,16 @@ func providerConfigureV2(_ context.Context, d *schema.ResourceData) (interface{}
 	return result, nil
 }
 
+// providerMeta is what providerConfigure returns and what CRUD callbacks
+// receive as meta: the provider's global configuration, plus, in
+// multi-provider mode, one configuration per logical provider prefix so a
+// call can be routed to the right script. See effectiveConfig.
+type providerMeta struct {
+	Global   *resourceConfig
+	ByPrefix map[string]*resourceConfig
+}
+
 func providerConfigure(d ResourceLike) (interface{}, error) {
-	configurationData := map[string]interface{}{}
-	for _, key := range []string{"id_key", "executor", "script", "environment", "javascript"} {
-		val, ok := d.GetOk(key)
-		if !ok {
-			continue
-		}
-		configurationData[key] = val
-	}
 	// Just check the environment is a map
 	e, ok := d.GetOk("environment")
 	if ok {
@@ -145,5 +240,35 @@ func providerConfigure(d ResourceLike) (interface{}, error) {
 			return nil, fmt.Errorf("environment - expected map[string]interface{} bit got %#v", e)
 		}
 	}
-	return configurationData, nil
+
+	cfg := &resourceConfig{}
+	if v, ok := d.GetOk("id_key"); ok {
+		cfg.IDKey = v.(string)
+	}
+	if v, ok := d.GetOk("executor"); ok {
+		cfg.Executor = v.(string)
+	}
+	if v, ok := d.GetOk("script"); ok {
+		cfg.Script = v.(string)
+	}
+	if v, ok := d.GetOk("environment"); ok {
+		env := map[string]string{}
+		for k, s := range v.(map[string]interface{}) {
+			env[k] = fmt.Sprintf("%v", s)
+		}
+		cfg.Environment = env
+	}
+	if v, ok := d.GetOk("javascript"); ok {
+		cfg.JavaScript = v.(string)
+	}
+	if v, ok := d.GetOk("javascript_timeout_seconds"); ok {
+		cfg.JavaScriptTimeout = time.Duration(v.(int)) * time.Second
+	}
+	if v, ok := d.GetOk("javascript_max_stack_size"); ok {
+		cfg.JavaScriptMaxStackSize = v.(int)
+	}
+	if v, ok := d.GetOk("javascript_max_memory_bytes"); ok {
+		cfg.JavaScriptMaxMemoryBytes = uint64(v.(int))
+	}
+	return &providerMeta{Global: cfg, ByPrefix: parseMultiProviders()}, nil
 }
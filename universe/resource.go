@@ -0,0 +1,227 @@
+package universe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ResourceLike is the subset of *schema.ResourceData that providerConfigure
+// needs, so it can be exercised with a fake in tests without standing up a
+// full schema.ResourceData.
+type ResourceLike interface {
+	GetOk(key string) (interface{}, bool)
+}
+
+// resourceConfig holds the executor/script/id_key/environment settings that
+// drive a single resource type. A value of this type is either the global
+// provider configuration (from providerConfigure) or a per-type override
+// (from a manifest entry, see manifest.go).
+type resourceConfig struct {
+	Executor    string
+	Script      string
+	IDKey       string
+	Environment map[string]string
+
+	// Provider, when set on a per-type override, explicitly names the
+	// logical provider (see multiprovider.go) this resource type routes to,
+	// overriding the default of routing by the type name's own prefix.
+	Provider string
+
+	// JavaScript, when set, is a path to a .js file (or inline source) run
+	// in-process with goja instead of spawning Executor/Script. See
+	// js_executor.go. JavaScriptMaxStackSize and JavaScriptMaxMemoryBytes
+	// cap, respectively, call-stack depth and Go heap growth per call; 0
+	// means "use the package default".
+	JavaScript               string
+	JavaScriptTimeout        time.Duration
+	JavaScriptMaxStackSize   int
+	JavaScriptMaxMemoryBytes uint64
+}
+
+// mergeResourceConfig overlays override on top of base, keeping base's
+// values for anything override leaves blank. This is how a per-type
+// manifest entry is combined with the provider's global defaults.
+func mergeResourceConfig(base, override *resourceConfig) *resourceConfig {
+	result := &resourceConfig{}
+	if base != nil {
+		*result = *base
+	}
+	if override == nil {
+		return result
+	}
+	if override.Executor != "" {
+		result.Executor = override.Executor
+	}
+	if override.Script != "" {
+		result.Script = override.Script
+	}
+	if override.IDKey != "" {
+		result.IDKey = override.IDKey
+	}
+	if override.Provider != "" {
+		result.Provider = override.Provider
+	}
+	if override.Environment != nil {
+		env := map[string]string{}
+		for k, v := range result.Environment {
+			env[k] = v
+		}
+		for k, v := range override.Environment {
+			env[k] = v
+		}
+		result.Environment = env
+	}
+	if override.JavaScript != "" {
+		result.JavaScript = override.JavaScript
+	}
+	if override.JavaScriptTimeout != 0 {
+		result.JavaScriptTimeout = override.JavaScriptTimeout
+	}
+	if override.JavaScriptMaxStackSize != 0 {
+		result.JavaScriptMaxStackSize = override.JavaScriptMaxStackSize
+	}
+	if override.JavaScriptMaxMemoryBytes != 0 {
+		result.JavaScriptMaxMemoryBytes = override.JavaScriptMaxMemoryBytes
+	}
+	return result
+}
+
+// resourceCustom returns the generic *schema.Resource registered for a
+// resource type. typeName is the resource's fully-qualified type (e.g.
+// "aws-lite_bucket"), used to route to the right logical provider in
+// multi-provider mode. override, when non-nil, is the per-type
+// configuration resolved for this resource type (see getResourceMap); it
+// takes precedence over the provider-level configuration stored in meta.
+func resourceCustom(typeName string, override *resourceConfig) *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCustomContext(typeName, override, "create"),
+		ReadContext:   resourceCustomContext(typeName, override, "read"),
+		UpdateContext: resourceCustomContext(typeName, override, "update"),
+		DeleteContext: resourceCustomContext(typeName, override, "delete"),
+		Schema: map[string]*schema.Schema{
+			"state": {
+				Description: "Opaque JSON state maintained by the external script.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceCustomContext builds the CRUD callback for a single verb
+// (create/read/update/delete), closing over the type name and per-type
+// override so the same resourceCustom() can back many differently
+// configured resource types.
+func resourceCustomContext(typeName string, override *resourceConfig, verb string) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		cfg, err := effectiveConfig(meta, typeName, override)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		state, err := runAction(cfg, verb, d.Get("state"))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if verb == "delete" {
+			d.SetId("")
+			return nil
+		}
+		if err := d.Set("state", state); err != nil {
+			return diag.FromErr(err)
+		}
+		id, err := extractID(cfg, state)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId(id)
+		return nil
+	}
+}
+
+// effectiveConfig resolves the resourceConfig to use for one CRUD call: the
+// provider's global configuration (meta), overlaid with the logical
+// provider's own configuration in multi-provider mode (selected by
+// typeName's prefix), overlaid with the resource type's own override, if
+// any.
+func effectiveConfig(meta interface{}, typeName string, override *resourceConfig) (*resourceConfig, error) {
+	pm, ok := meta.(*providerMeta)
+	if !ok {
+		return nil, fmt.Errorf("provider meta has unexpected type %T", meta)
+	}
+	base := pm.Global
+	if logical, ok := pm.ByPrefix[logicalProviderFor(typeName, override)]; ok {
+		base = mergeResourceConfig(pm.Global, logical)
+	}
+	cfg := mergeResourceConfig(base, override)
+	if cfg.JavaScript == "" && (cfg.Executor == "" || cfg.Script == "") {
+		return nil, fmt.Errorf("resource has no executor/script or javascript configured")
+	}
+	return cfg, nil
+}
+
+// runAction executes one CRUD verb against a resource, choosing between the
+// in-process JavaScript executor and the default subprocess executor
+// depending on cfg.
+func runAction(cfg *resourceConfig, verb string, state interface{}) (string, error) {
+	if cfg.JavaScript != "" {
+		return runJavaScript(cfg, verb, state)
+	}
+	return runScript(cfg, verb, state)
+}
+
+// runScript invokes cfg.Executor with cfg.Script and verb as arguments,
+// passing state as JSON on stdin and cfg.Environment added on top of the
+// process's own environment in the child. It returns the JSON state
+// printed on stdout; if the process fails, any output on stderr is
+// appended to the returned error so script-side diagnostics reach the
+// user instead of being swallowed.
+func runScript(cfg *resourceConfig, verb string, state interface{}) (string, error) {
+	cmd := exec.Command(cfg.Executor, cfg.Script, verb)
+	cmd.Env = os.Environ()
+	for k, v := range cfg.Environment {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	in, err := json.Marshal(state)
+	if err != nil {
+		return "", fmt.Errorf("marshalling state: %w", err)
+	}
+	cmd.Stdin = bytes.NewReader(in)
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return "", fmt.Errorf("running %s %s %s: %w: %s", cfg.Executor, cfg.Script, verb, err, strings.TrimSpace(stderr.String()))
+		}
+		return "", fmt.Errorf("running %s %s %s: %w", cfg.Executor, cfg.Script, verb, err)
+	}
+	return out.String(), nil
+}
+
+// extractID pulls the unique identifier out of a script's JSON state using
+// cfg.IDKey (defaulting to "id").
+func extractID(cfg *resourceConfig, state string) (string, error) {
+	idKey := cfg.IDKey
+	if idKey == "" {
+		idKey = "id"
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(state), &parsed); err != nil {
+		return "", fmt.Errorf("parsing script output as JSON: %w", err)
+	}
+	id, ok := parsed[idKey]
+	if !ok {
+		return "", fmt.Errorf("script output has no %q key", idKey)
+	}
+	return fmt.Sprintf("%v", id), nil
+}